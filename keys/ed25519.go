@@ -0,0 +1,101 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+const ed25519SeedKey = "ed25519 seed"
+
+// Ed25519ExtendedKey is an extended Ed25519 private key derived per
+// SLIP-0010. Unlike BIP32, SLIP-0010 ed25519 derivation supports hardened
+// child indices only.
+type Ed25519ExtendedKey struct {
+	PrivateKey [32]byte
+	ChainCode  [32]byte
+}
+
+// NewEd25519MasterFromMnemonic derives a SLIP-0010 ed25519 master extended
+// key from a BIP39 mnemonic phrase and optional passphrase, reusing the
+// same PBKDF2-HMAC-SHA512 seed as NewMasterFromMnemonic.
+func NewEd25519MasterFromMnemonic(mnemonic, passphrase string) *Ed25519ExtendedKey {
+	return newEd25519MasterFromSeed(mnemonicSeed(mnemonic, passphrase))
+}
+
+func newEd25519MasterFromSeed(seed []byte) *Ed25519ExtendedKey {
+	mac := hmac.New(sha512.New, []byte(ed25519SeedKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key := &Ed25519ExtendedKey{}
+	copy(key.PrivateKey[:], sum[:32])
+	copy(key.ChainCode[:], sum[32:])
+	return key
+}
+
+// HardenedChild derives the hardened child extended key at the
+// non-hardened index (index < hdkeychain.HardenedKeyStart), offsetting it
+// into the hardened range itself so callers cannot trigger a uint32
+// overflow by adding hdkeychain.HardenedKeyStart to an index that is
+// already in, or close to, the hardened range.
+func (k *Ed25519ExtendedKey) HardenedChild(index uint32) (*Ed25519ExtendedKey, error) {
+	if index >= hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("index %d is already in the hardened range", index)
+	}
+	return k.Child(index + hdkeychain.HardenedKeyStart)
+}
+
+// Child derives the hardened child extended key at index per SLIP-0010.
+// index must already be offset into the hardened range
+// (hdkeychain.HardenedKeyStart or above); SLIP-0010 ed25519 has no
+// non-hardened derivation.
+func (k *Ed25519ExtendedKey) Child(index uint32) (*Ed25519ExtendedKey, error) {
+	if index < hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("ed25519 derivation only supports hardened indices")
+	}
+	var data [37]byte
+	copy(data[1:33], k.PrivateKey[:])
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, k.ChainCode[:])
+	mac.Write(data[:])
+	sum := mac.Sum(nil)
+	child := &Ed25519ExtendedKey{}
+	copy(child.PrivateKey[:], sum[:32])
+	copy(child.ChainCode[:], sum[32:])
+	return child, nil
+}
+
+// PublicKey returns the Ed25519 public key for this extended key.
+func (k *Ed25519ExtendedKey) PublicKey() ed25519.PublicKey {
+	priv := ed25519.NewKeyFromSeed(k.PrivateKey[:])
+	return priv.Public().(ed25519.PublicKey)
+}
+
+// DeriveEd25519Path walks key down a derivation path, e.g.
+// "m/44'/223'/0'/0", deriving every segment as a hardened SLIP-0010 index
+// regardless of whether it is marked with a trailing '.
+func DeriveEd25519Path(key *Ed25519ExtendedKey, path string) (*Ed25519ExtendedKey, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	result := key
+	for _, segment := range segments[1:] {
+		segment = strings.TrimSuffix(segment, "'")
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path: %s", path)
+		}
+		result, err = result.HardenedChild(uint32(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// TestEd25519SLIP0010Vector pins newEd25519MasterFromSeed and Child against
+// SLIP-0010's published test vector 1 (seed 000102030405060708090a0b0c0d0e0f).
+func TestEd25519SLIP0010Vector(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	master := newEd25519MasterFromSeed(seed)
+	if got := hex.EncodeToString(master.PrivateKey[:]); got != "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7" {
+		t.Fatalf("master private key: got %s", got)
+	}
+	if got := hex.EncodeToString(master.ChainCode[:]); got != "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb" {
+		t.Fatalf("master chain code: got %s", got)
+	}
+	if got := hex.EncodeToString(master.PublicKey()); got != "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed" {
+		t.Fatalf("master public key: got %s", got)
+	}
+
+	child, err := master.HardenedChild(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(child.PrivateKey[:]); got != "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3" {
+		t.Fatalf("m/0' private key: got %s", got)
+	}
+	if got := hex.EncodeToString(child.ChainCode[:]); got != "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69" {
+		t.Fatalf("m/0' chain code: got %s", got)
+	}
+	if got := hex.EncodeToString(child.PublicKey()); got != "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c" {
+		t.Fatalf("m/0' public key: got %s", got)
+	}
+}
+
+func TestEd25519ChildRejectsNonHardened(t *testing.T) {
+	master := newEd25519MasterFromSeed([]byte("0123456789abcdef0123456789abcdef"))
+	if _, err := master.Child(0); err == nil {
+		t.Fatal("expected error deriving a non-hardened index")
+	}
+}
+
+func TestEd25519HardenedChildRejectsOverflow(t *testing.T) {
+	master := newEd25519MasterFromSeed([]byte("0123456789abcdef0123456789abcdef"))
+	if _, err := master.HardenedChild(hdkeychain.HardenedKeyStart); err == nil {
+		t.Fatal("expected error for an index already in the hardened range")
+	}
+}
+
+func TestDeriveEd25519Path(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	master := newEd25519MasterFromSeed(seed)
+
+	got, err := DeriveEd25519Path(master, "m/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := master.HardenedChild(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(got.PrivateKey[:]) != hex.EncodeToString(want.PrivateKey[:]) {
+		t.Fatalf("got %x, want %x", got.PrivateKey, want.PrivateKey)
+	}
+
+	if _, err := DeriveEd25519Path(master, "44'/0"); err == nil {
+		t.Fatal("expected error for a path missing the leading m")
+	}
+	if _, err := DeriveEd25519Path(master, "m/abc"); err == nil {
+		t.Fatal("expected error for an invalid segment")
+	}
+}
@@ -0,0 +1,68 @@
+// Package keys provides BIP32/BIP39/BIP44 key derivation helpers used to
+// build principals without going through the xpubprincipals CLI.
+package keys
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SeedSize is the length, in bytes, of the seed produced from a BIP39
+// mnemonic.
+const SeedSize = 64
+
+// NewMasterFromMnemonic derives a BIP32 master extended key from a BIP39
+// mnemonic phrase and optional passphrase. The seed is computed as
+// PBKDF2-HMAC-SHA512 with 2048 iterations and salt "mnemonic"+passphrase,
+// per BIP39; the master key is then generated per BIP32 using HMAC-SHA512
+// keyed with "Bitcoin seed".
+func NewMasterFromMnemonic(mnemonic, passphrase string) (*hdkeychain.ExtendedKey, error) {
+	return hdkeychain.NewMaster(mnemonicSeed(mnemonic, passphrase), &chaincfg.MainNetParams)
+}
+
+// mnemonicSeed derives the 64-byte BIP39 seed from a mnemonic phrase and
+// optional passphrase.
+func mnemonicSeed(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key(
+		[]byte(mnemonic),
+		[]byte("mnemonic"+passphrase),
+		2048,
+		SeedSize,
+		sha512.New,
+	)
+}
+
+// DerivePath walks key down a BIP44-style derivation path, e.g.
+// "m/44'/223'/0'/0", where a trailing ' marks a hardened index.
+func DerivePath(key *hdkeychain.ExtendedKey, path string) (*hdkeychain.ExtendedKey, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	result := key
+	for _, segment := range segments[1:] {
+		hardened := strings.HasSuffix(segment, "'")
+		segment = strings.TrimSuffix(segment, "'")
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path: %s", path)
+		}
+		if hardened {
+			if index >= hdkeychain.HardenedKeyStart {
+				return nil, fmt.Errorf("invalid derivation path: %s: index %d is already in the hardened range", path, index)
+			}
+			index += hdkeychain.HardenedKeyStart
+		}
+		result, err = result.Child(uint32(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// TestMnemonicSeedBIP39Vector pins mnemonicSeed against the standard BIP39
+// test vector (mnemonic "abandon...about" with passphrase "TREZOR").
+func TestMnemonicSeedBIP39Vector(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	passphrase := "TREZOR"
+	want := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	got := hex.EncodeToString(mnemonicSeed(mnemonic, passphrase))
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestDerivePath(t *testing.T) {
+	master, err := NewMasterFromMnemonic(
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"TREZOR",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("hardened and non-hardened mix", func(t *testing.T) {
+		got, err := DerivePath(master, "m/44'/223'/0'/0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := master.Child(44 + hdkeychain.HardenedKeyStart)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err = want.Child(223 + hdkeychain.HardenedKeyStart)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err = want.Child(0 + hdkeychain.HardenedKeyStart)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err = want.Child(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Fatalf("got %s, want %s", got.String(), want.String())
+		}
+	})
+
+	t.Run("missing leading m", func(t *testing.T) {
+		if _, err := DerivePath(master, "44'/0"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("invalid segment", func(t *testing.T) {
+		if _, err := DerivePath(master, "m/abc"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("hardened segment already in the hardened range", func(t *testing.T) {
+		if _, err := DerivePath(master, "m/4294967295'"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
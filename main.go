@@ -1,80 +1,158 @@
 package main
 
 import (
-	"crypto/elliptic"
-	"crypto/sha256"
-	"encoding/asn1"
-	"encoding/base32"
-	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
-	"hash/crc32"
 	"os"
-	"strings"
 
-	"github.com/btcsuite/btcd/btcec"
-	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/enzoh/xpubprincipals/keys"
+	"github.com/enzoh/xpubprincipals/principals"
 )
 
-type ECPubKeyMetadata struct {
-	ECPubKeyOID   asn1.ObjectIdentifier
-	NamedCurveOID asn1.ObjectIdentifier
-}
-
-type ECPubKey struct {
-	Metadata  ECPubKeyMetadata
-	PublicKey asn1.BitString
-}
+const (
+	curveSecp256k1 = "secp256k1"
+	curveEd25519   = "ed25519"
+)
 
 func main() {
-	argXPubKey := flag.String("xpub", "", "Extended public key. (required)")
-	argN := flag.Int("n", 8, "Number of addresses.")
+	argXPubKey := flag.String("xpub", "", "Extended public key.")
+	argMnemonic := flag.String("mnemonic", "", "BIP39 mnemonic phrase.")
+	argPassphrase := flag.String("passphrase", "", "BIP39 mnemonic passphrase.")
+	argPath := flag.String("path", "m/44'/223'/0'/0", "BIP44-style derivation path. (used with -mnemonic)")
+	argStart := flag.Uint("start", 0, "First child index.")
+	argN := flag.Uint("n", 8, "Number of addresses.")
+	argCurve := flag.String("curve", curveSecp256k1, "Curve to derive principals on. (secp256k1|ed25519)")
+	argFormat := flag.String("format", "text", "Output format. (text|json|csv)")
+	argFind := flag.String("find", "", "Principal to search for among -xpub's derived children. (requires -xpub)")
+	argVerify := flag.String("verify", "", "Principal to validate and decode.")
 	flag.Parse()
-	if *argXPubKey == "" {
+	if *argVerify != "" {
+		err := runVerify(*argVerify)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *argFind != "" {
+		err := runFind(*argXPubKey, *argFind, uint32(*argStart), uint32(*argN))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *argXPubKey == "" && *argMnemonic == "" {
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
-	err := run(*argXPubKey, *argN)
+	err := run(*argXPubKey, *argMnemonic, *argPassphrase, *argPath, uint32(*argStart), uint32(*argN), *argCurve, *argFormat)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run(argXPubKey string, argN int) error {
-	masterXPubKey, err := hdkeychain.NewKeyFromString(argXPubKey)
+// runVerify validates and decodes a principal string, reporting its class
+// and, for self-authenticating principals, the digest it encodes.
+func runVerify(argVerify string) error {
+	principal, err := principals.Parse(argVerify)
 	if err != nil {
 		return err
 	}
-	principals, err := generate(masterXPubKey, argN)
+	fmt.Printf("%s valid, class=%s", principal.Text, principal.Class)
+	if principal.Class == principals.ClassSelfAuthenticating {
+		fmt.Printf(", digest=%s", hex.EncodeToString(principal.Digest[:]))
+	}
+	fmt.Println()
+	return nil
+}
+
+// runFind scans xpub's external (m/0) and internal (m/1) chains for a
+// child deriving to the target principal.
+func runFind(argXPubKey string, argFind string, argStart uint32, argN uint32) error {
+	if argXPubKey == "" {
+		return fmt.Errorf("-find requires -xpub")
+	}
+	result, err := principals.Find(argXPubKey, argFind, principals.ScanOptions{
+		Start: argStart,
+		Count: argN,
+	})
 	if err != nil {
 		return err
 	}
-	for _, principal := range principals {
-		fmt.Println(principal)
+	if !result.Found {
+		return fmt.Errorf("principal not found in range [%d, %d) on chains m/0, m/1", argStart, argStart+argN)
 	}
+	fmt.Printf("%s (index %d)\n", result.Path, result.Index)
 	return nil
 }
 
-func generate(
-	masterXPubKey *hdkeychain.ExtendedKey,
-	n int,
-) ([]string, error) {
-	masterXPubKey0, err := masterXPubKey.Child(0)
+func run(
+	argXPubKey string,
+	argMnemonic string,
+	argPassphrase string,
+	argPath string,
+	argStart uint32,
+	argN uint32,
+	argCurve string,
+	argFormat string,
+) error {
+	var result []principals.Principal
+	var err error
+	switch argCurve {
+	case curveEd25519:
+		result, err = generateEd25519(argMnemonic, argPassphrase, argPath, argStart, argN)
+	case curveSecp256k1:
+		result, err = generateSecp256k1(argXPubKey, argMnemonic, argPassphrase, argPath, argStart, argN)
+	default:
+		err = fmt.Errorf("unsupported curve: %s", argCurve)
+	}
+	if err != nil {
+		return err
+	}
+	return printPrincipals(result, argFormat)
+}
+
+// generateSecp256k1 derives principals either from a serialized xpub's
+// external chain (m/0) or, given a mnemonic, from argPath beneath the
+// BIP32 master key.
+func generateSecp256k1(
+	argXPubKey string,
+	argMnemonic string,
+	argPassphrase string,
+	argPath string,
+	argStart uint32,
+	argN uint32,
+) ([]principals.Principal, error) {
+	if argMnemonic == "" {
+		return principals.Generate(argXPubKey, argStart, argN)
+	}
+	if err := principals.ValidateRange(argStart, argN); err != nil {
+		return nil, err
+	}
+	masterKey, err := keys.NewMasterFromMnemonic(argMnemonic, argPassphrase)
 	if err != nil {
 		return nil, err
 	}
-	result := make([]string, 0)
-	for i := 0; i < n; i++ {
-		childXPubKey, err := masterXPubKey0.Child(uint32(i))
+	accountKey, err := keys.DerivePath(masterKey, argPath)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]principals.Principal, 0, argN)
+	for i := argStart; i < argStart+argN; i++ {
+		childKey, err := accountKey.Child(i)
 		if err != nil {
 			return nil, err
 		}
-		pubKey, err := childXPubKey.ECPubKey()
+		pubKey, err := childKey.ECPubKey()
 		if err != nil {
 			return nil, err
 		}
-		principal, err := ECPubKeyToPrincipal(pubKey)
+		principal, err := principals.NewPrincipal(i, fmt.Sprintf("%s/%d", argPath, i), pubKey)
 		if err != nil {
 			return nil, err
 		}
@@ -83,60 +161,99 @@ func generate(
 	return result, nil
 }
 
-func ECPubKeyToPrincipal(pubKey *btcec.PublicKey) (string, error) {
-	der, err := EncodeECPubKey(pubKey)
+// generateEd25519 derives principals at argPath beneath the SLIP-0010
+// ed25519 master key for a mnemonic, iterating argN hardened child
+// indices (ed25519 SLIP-0010 derivation supports hardened indices only).
+func generateEd25519(
+	argMnemonic string,
+	argPassphrase string,
+	argPath string,
+	argStart uint32,
+	argN uint32,
+) ([]principals.Principal, error) {
+	if argMnemonic == "" {
+		return nil, fmt.Errorf("-curve ed25519 requires -mnemonic")
+	}
+	if err := principals.ValidateRange(argStart, argN); err != nil {
+		return nil, err
+	}
+	masterKey := keys.NewEd25519MasterFromMnemonic(argMnemonic, argPassphrase)
+	accountKey, err := keys.DeriveEd25519Path(masterKey, argPath)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return SelfAuthenticating(der), nil
+	result := make([]principals.Principal, 0, argN)
+	for i := argStart; i < argStart+argN; i++ {
+		childKey, err := accountKey.HardenedChild(i)
+		if err != nil {
+			return nil, err
+		}
+		principal, err := principals.NewPrincipal(i, fmt.Sprintf("%s/%d'", argPath, i), childKey.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, principal)
+	}
+	return result, nil
 }
 
-func EncodeECPubKey(pubKey *btcec.PublicKey) ([]byte, error) {
-	curve := btcec.S256()
-	point := pubKey.ToECDSA()
-	return asn1.Marshal(ECPubKey{
-		Metadata: ECPubKeyMetadata{
-			ECPubKeyOID:   asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
-			NamedCurveOID: SECP256K1(),
-		},
-		PublicKey: asn1.BitString{
-			Bytes: elliptic.Marshal(curve, point.X, point.Y),
-		},
-	})
+func printPrincipals(result []principals.Principal, format string) error {
+	switch format {
+	case "json":
+		return printPrincipalsJSON(result)
+	case "csv":
+		return printPrincipalsCSV(result)
+	case "text":
+		for _, principal := range result {
+			fmt.Println(principal.Text)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
 }
 
-func SECP256K1() asn1.ObjectIdentifier {
-	return asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+type principalJSON struct {
+	Index  uint32 `json:"index"`
+	Path   string `json:"path"`
+	DER    string `json:"der"`
+	Digest string `json:"digest"`
+	Text   string `json:"text"`
 }
 
-func SelfAuthenticating(der []byte) string {
-	digest := sha256.Sum224(der)
-	tag := []byte{2}
-	data := append(digest[:], tag...)
-	crc := make([]byte, 4)
-	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(data))
-	encoder := base32.StdEncoding.WithPadding(base32.NoPadding)
-	str := encoder.EncodeToString(append(crc, data...))
-	return strings.Join(SplitN(strings.ToLower(str), 5), "-")
+func printPrincipalsJSON(result []principals.Principal) error {
+	rows := make([]principalJSON, len(result))
+	for i, principal := range result {
+		rows[i] = principalJSON{
+			Index:  principal.Index,
+			Path:   principal.Path,
+			DER:    hex.EncodeToString(principal.DER),
+			Digest: hex.EncodeToString(principal.Digest[:]),
+			Text:   principal.Text,
+		}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
 }
 
-func SplitN(str string, n int) []string {
-	if n >= len(str) {
-		return []string{str}
-	}
-	var chunks []string
-	chunk := make([]rune, n)
-	i := 0
-	for _, r := range str {
-		chunk[i] = r
-		i++
-		if i == n {
-			chunks = append(chunks, string(chunk))
-			i = 0
-		}
+func printPrincipalsCSV(result []principals.Principal) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write([]string{"index", "path", "der", "digest", "text"}); err != nil {
+		return err
 	}
-	if i > 0 {
-		chunks = append(chunks, string(chunk[:i]))
+	for _, principal := range result {
+		row := []string{
+			fmt.Sprintf("%d", principal.Index),
+			principal.Path,
+			hex.EncodeToString(principal.DER),
+			hex.EncodeToString(principal.Digest[:]),
+			principal.Text,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
 	}
-	return chunks
+	writer.Flush()
+	return writer.Error()
 }
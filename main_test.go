@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/enzoh/xpubprincipals/principals"
+)
+
+func testPrincipal(t *testing.T, index uint32, path string) principals.Principal {
+	t.Helper()
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), bytes.Repeat([]byte{0x01}, 32))
+	principal, err := principals.NewPrincipal(index, path, pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return principal
+}
+
+func capturePrintPrincipals(t *testing.T, result []principals.Principal, format string) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	if err := printPrincipals(result, format); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestPrintPrincipalsJSON(t *testing.T) {
+	principal := testPrincipal(t, 0, "m/0/0")
+	out := capturePrintPrincipals(t, []principals.Principal{principal}, "json")
+
+	var rows []principalJSON
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	if rows[0].Index != 0 || rows[0].Path != "m/0/0" || rows[0].Text != principal.Text {
+		t.Fatalf("got %+v", rows[0])
+	}
+	if rows[0].DER != hex.EncodeToString(principal.DER) {
+		t.Fatalf("got DER %s, want %s", rows[0].DER, hex.EncodeToString(principal.DER))
+	}
+}
+
+func TestPrintPrincipalsCSV(t *testing.T) {
+	principal := testPrincipal(t, 1, "m/0/1")
+	out := capturePrintPrincipals(t, []principals.Principal{principal}, "csv")
+
+	records, err := csv.NewReader(bytes.NewBufferString(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (header + row)", len(records))
+	}
+	if got := records[0]; got[0] != "index" || got[1] != "path" || got[4] != "text" {
+		t.Fatalf("unexpected header: %v", got)
+	}
+	if got := records[1]; got[0] != "1" || got[1] != "m/0/1" || got[4] != principal.Text {
+		t.Fatalf("unexpected row: %v", got)
+	}
+}
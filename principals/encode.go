@@ -0,0 +1,133 @@
+package principals
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+type ECPubKeyMetadata struct {
+	ECPubKeyOID   asn1.ObjectIdentifier
+	NamedCurveOID asn1.ObjectIdentifier
+}
+
+type ECPubKey struct {
+	Metadata  ECPubKeyMetadata
+	PublicKey asn1.BitString
+}
+
+type Ed25519PubKeyMetadata struct {
+	Algorithm asn1.ObjectIdentifier
+}
+
+type Ed25519PubKey struct {
+	Metadata  Ed25519PubKeyMetadata
+	PublicKey asn1.BitString
+}
+
+// PubKeyToPrincipal derives the self-authenticating principal for pub,
+// dispatching on its concrete type to produce the correct
+// SubjectPublicKeyInfo encoding.
+func PubKeyToPrincipal(pub crypto.PublicKey) (string, error) {
+	der, err := EncodePubKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return SelfAuthenticating(der), nil
+}
+
+// EncodePubKey DER-encodes pub as a SubjectPublicKeyInfo. secp256k1 keys
+// (*btcec.PublicKey) use the EC public key OID with the secp256k1 named
+// curve; ed25519 keys (ed25519.PublicKey) use the ed25519 OID with the raw
+// 32-byte key and no algorithm parameters.
+func EncodePubKey(pub crypto.PublicKey) ([]byte, error) {
+	switch key := pub.(type) {
+	case *btcec.PublicKey:
+		return encodeECPubKey(key)
+	case ed25519.PublicKey:
+		return encodeEd25519PubKey(key)
+	default:
+		return nil, fmt.Errorf("unsupported public key type: %T", pub)
+	}
+}
+
+func encodeECPubKey(pubKey *btcec.PublicKey) ([]byte, error) {
+	curve := btcec.S256()
+	point := pubKey.ToECDSA()
+	return asn1.Marshal(ECPubKey{
+		Metadata: ECPubKeyMetadata{
+			ECPubKeyOID:   asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			NamedCurveOID: SECP256K1(),
+		},
+		PublicKey: asn1.BitString{
+			Bytes: elliptic.Marshal(curve, point.X, point.Y),
+		},
+	})
+}
+
+func encodeEd25519PubKey(pubKey ed25519.PublicKey) ([]byte, error) {
+	return asn1.Marshal(Ed25519PubKey{
+		Metadata: Ed25519PubKeyMetadata{
+			Algorithm: ED25519(),
+		},
+		PublicKey: asn1.BitString{
+			Bytes: []byte(pubKey),
+		},
+	})
+}
+
+func SECP256K1() asn1.ObjectIdentifier {
+	return asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+}
+
+func ED25519() asn1.ObjectIdentifier {
+	return asn1.ObjectIdentifier{1, 3, 101, 112}
+}
+
+func SelfAuthenticating(der []byte) string {
+	digest := sha256.Sum224(der)
+	tag := []byte{2}
+	data := append(digest[:], tag...)
+	return formatPrincipal(data)
+}
+
+// formatPrincipal renders data (the principal's raw bytes, excluding the
+// checksum) as the textual, dash-grouped form: base32(crc32(data)+data),
+// lowercased and split into 5-character groups.
+func formatPrincipal(data []byte) string {
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(data))
+	encoder := base32.StdEncoding.WithPadding(base32.NoPadding)
+	str := encoder.EncodeToString(append(crc, data...))
+	return strings.Join(SplitN(strings.ToLower(str), 5), "-")
+}
+
+func SplitN(str string, n int) []string {
+	if n >= len(str) {
+		return []string{str}
+	}
+	var chunks []string
+	chunk := make([]rune, n)
+	i := 0
+	for _, r := range str {
+		chunk[i] = r
+		i++
+		if i == n {
+			chunks = append(chunks, string(chunk))
+			i = 0
+		}
+	}
+	if i > 0 {
+		chunks = append(chunks, string(chunk[:i]))
+	}
+	return chunks
+}
@@ -0,0 +1,74 @@
+package principals
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// ScanOptions bounds a Find scan.
+type ScanOptions struct {
+	// Start is the first child index scanned on each chain.
+	Start uint32
+	// Count is the number of child indices scanned on each chain.
+	Count uint32
+	// Chains lists the BIP44-style chain indices to scan, e.g. 0 for the
+	// external chain (m/0) and 1 for the internal chain (m/1). If empty,
+	// both the external and internal chains are scanned.
+	Chains []uint32
+}
+
+// ScanResult reports where, if anywhere, Find located the target
+// principal.
+type ScanResult struct {
+	// Found is true if target was derived within the scanned range.
+	Found bool
+	// Chain is the chain index the target was found on.
+	Chain uint32
+	// Index is the child index the target was found at.
+	Index uint32
+	// Path is the full derivation path the target was found at.
+	Path string
+}
+
+// Find scans child indices [opts.Start, opts.Start+opts.Count) of xpub on
+// each of opts.Chains looking for a child that derives to the principal
+// target, analogous to gap-limit address scanning in HD wallets. It
+// reports the first chain and index at which target is found, if any.
+func Find(xpub string, target string, opts ScanOptions) (ScanResult, error) {
+	if err := ValidateRange(opts.Start, opts.Count); err != nil {
+		return ScanResult{}, err
+	}
+	masterXPubKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return ScanResult{}, err
+	}
+	chains := opts.Chains
+	if len(chains) == 0 {
+		chains = []uint32{0, 1}
+	}
+	for _, chain := range chains {
+		chainKey, err := masterXPubKey.Child(chain)
+		if err != nil {
+			return ScanResult{}, err
+		}
+		for i := opts.Start; i < opts.Start+opts.Count; i++ {
+			childKey, err := chainKey.Child(i)
+			if err != nil {
+				return ScanResult{}, err
+			}
+			pubKey, err := childKey.ECPubKey()
+			if err != nil {
+				return ScanResult{}, err
+			}
+			principal, err := NewPrincipal(i, fmt.Sprintf("m/%d/%d", chain, i), pubKey)
+			if err != nil {
+				return ScanResult{}, err
+			}
+			if principal.Text == target {
+				return ScanResult{Found: true, Chain: chain, Index: i, Path: principal.Path}, nil
+			}
+		}
+	}
+	return ScanResult{}, nil
+}
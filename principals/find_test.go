@@ -0,0 +1,104 @@
+package principals
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func mustXPubKey(t *testing.T, xpub string) *hdkeychain.ExtendedKey {
+	t.Helper()
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestFind(t *testing.T) {
+	xpub := testXPub(t)
+
+	result, err := Generate(xpub, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := result[3]
+
+	found, err := Find(xpub, target.Text, ScanOptions{Start: 0, Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Found {
+		t.Fatal("expected to find target")
+	}
+	if found.Chain != 0 {
+		t.Fatalf("got chain %d, want 0", found.Chain)
+	}
+	if found.Index != 3 {
+		t.Fatalf("got index %d, want 3", found.Index)
+	}
+	if found.Path != "m/0/3" {
+		t.Fatalf("got path %s, want m/0/3", found.Path)
+	}
+}
+
+func TestFindNotFound(t *testing.T) {
+	xpub := testXPub(t)
+
+	result, err := Generate(xpub, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := Find(xpub, result[0].Text, ScanOptions{Start: 0, Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Found {
+		t.Fatalf("expected not found, got %+v", found)
+	}
+}
+
+func TestFindScansInternalChain(t *testing.T) {
+	xpub := testXPub(t)
+
+	masterXPubKey := mustXPubKey(t, xpub)
+	internalChainKey, err := masterXPubKey.Child(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	childKey, err := internalChainKey.Child(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := childKey.ECPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := NewPrincipal(2, "m/1/2", pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := Find(xpub, target.Text, ScanOptions{Start: 0, Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Found {
+		t.Fatal("expected to find target on the internal chain")
+	}
+	if found.Chain != 1 {
+		t.Fatalf("got chain %d, want 1", found.Chain)
+	}
+	if found.Index != 2 {
+		t.Fatalf("got index %d, want 2", found.Index)
+	}
+}
+
+func TestFindOverflow(t *testing.T) {
+	xpub := testXPub(t)
+
+	if _, err := Find(xpub, "anything", ScanOptions{Start: 4294967295, Count: 5}); err == nil {
+		t.Fatal("expected an error for an overflowing start+count")
+	}
+}
@@ -0,0 +1,93 @@
+package principals
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// Class identifies which of the IC's principal classes a principal
+// belongs to, as encoded in its trailing tag byte.
+type Class byte
+
+const (
+	// ClassManagementCanister is not a tagged class at all: the
+	// management canister's principal is the empty byte string, with no
+	// trailing tag byte. It is assigned the zero Class value, since real
+	// tag bytes start at 0x01.
+	ClassManagementCanister Class = 0x00
+	ClassOpaque             Class = 0x01
+	ClassSelfAuthenticating Class = 0x02
+	ClassDerived            Class = 0x03
+	ClassAnonymous          Class = 0x04
+)
+
+func (c Class) String() string {
+	switch c {
+	case ClassManagementCanister:
+		return "management-canister"
+	case ClassOpaque:
+		return "opaque"
+	case ClassSelfAuthenticating:
+		return "self-authenticating"
+	case ClassDerived:
+		return "derived"
+	case ClassAnonymous:
+		return "anonymous"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", byte(c))
+	}
+}
+
+// Parse decodes and validates a textual principal, reversing
+// SelfAuthenticating's encoding: it strips dashes, base32-decodes
+// (case-insensitively, without padding), splits off the leading 4-byte
+// CRC32 and verifies it against the remainder, and infers the principal
+// Class from the trailing tag byte. For self-authenticating principals
+// the preceding 28 bytes are also validated and returned as Digest, since
+// that is the only class with a fixed-length SHA-224 payload.
+func Parse(s string) (Principal, error) {
+	encoded := strings.ToUpper(strings.ReplaceAll(s, "-", ""))
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(encoded)
+	if err != nil {
+		return Principal{}, fmt.Errorf("invalid principal encoding: %w", err)
+	}
+	if len(decoded) < 4 {
+		return Principal{}, fmt.Errorf("principal too short")
+	}
+	crc, data := decoded[:4], decoded[4:]
+	want := make([]byte, 4)
+	binary.BigEndian.PutUint32(want, crc32.ChecksumIEEE(data))
+	if !bytes.Equal(crc, want) {
+		return Principal{}, fmt.Errorf("principal checksum mismatch")
+	}
+	// The management canister's principal is the empty byte string: no
+	// tag byte, no payload.
+	if len(data) == 0 {
+		return Principal{Class: ClassManagementCanister, Text: formatPrincipal(data)}, nil
+	}
+	tag := Class(data[len(data)-1])
+	payload := data[:len(data)-1]
+	switch tag {
+	case ClassOpaque, ClassSelfAuthenticating, ClassDerived, ClassAnonymous:
+	default:
+		return Principal{}, fmt.Errorf("unknown principal class tag: 0x%02x", byte(tag))
+	}
+	principal := Principal{Class: tag}
+	switch tag {
+	case ClassSelfAuthenticating:
+		if len(payload) != 28 {
+			return Principal{}, fmt.Errorf("self-authenticating principal digest must be 28 bytes, got %d", len(payload))
+		}
+		copy(principal.Digest[:], payload)
+	case ClassAnonymous:
+		if len(payload) != 0 {
+			return Principal{}, fmt.Errorf("anonymous principal must have no payload, got %d bytes", len(payload))
+		}
+	}
+	principal.Text = formatPrincipal(data)
+	return principal, nil
+}
@@ -0,0 +1,58 @@
+package principals
+
+import "testing"
+
+func TestParseManagementCanister(t *testing.T) {
+	principal, err := Parse("aaaaa-aa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.Class != ClassManagementCanister {
+		t.Fatalf("got class %s, want %s", principal.Class, ClassManagementCanister)
+	}
+	if got := principal.Class.String(); got != "management-canister" {
+		t.Fatalf("got class string %q, want %q", got, "management-canister")
+	}
+}
+
+func TestParseAnonymous(t *testing.T) {
+	principal, err := Parse("2vxsx-fae")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.Class != ClassAnonymous {
+		t.Fatalf("got class %s, want %s", principal.Class, ClassAnonymous)
+	}
+}
+
+func TestParseAnonymousRejectsNonEmptyPayload(t *testing.T) {
+	// formatPrincipal(0x00 0x04) packs an arbitrary padding byte in front of
+	// the anonymous tag, which must not validate as class=anonymous.
+	text := formatPrincipal([]byte{0x00, 0x04})
+	if _, err := Parse(text); err == nil {
+		t.Fatal("expected an error for an anonymous principal with a non-empty payload")
+	}
+}
+
+func TestParseSelfAuthenticatingRoundTrip(t *testing.T) {
+	der := []byte("not a real der encoding, just needs distinct bytes")
+	text := SelfAuthenticating(der)
+	principal, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.Class != ClassSelfAuthenticating {
+		t.Fatalf("got class %s, want %s", principal.Class, ClassSelfAuthenticating)
+	}
+	if principal.Text != text {
+		t.Fatalf("got text %s, want %s", principal.Text, text)
+	}
+}
+
+func TestParseChecksumMismatch(t *testing.T) {
+	text := formatPrincipal([]byte{0x01, 0x02, 0x03, 0x04})
+	corrupted := "z" + text[1:]
+	if _, err := Parse(corrupted); err == nil {
+		t.Fatal("expected an error for a bad checksum")
+	}
+}
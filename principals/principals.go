@@ -0,0 +1,92 @@
+// Package principals derives IC self-authenticating principals from
+// secp256k1 and Ed25519 public keys, and exposes that logic as a library
+// so it does not have to be consumed through the xpubprincipals CLI.
+package principals
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// Principal is one derived principal along with the derivation metadata
+// needed to reproduce it.
+type Principal struct {
+	// Index is the child index used to derive this principal.
+	Index uint32
+	// Path is the full derivation path, e.g. "m/0/3".
+	Path string
+	// DER is the ASN.1 SubjectPublicKeyInfo encoding of the public key.
+	DER []byte
+	// Digest is the SHA-224 digest of DER. Only meaningful when Class is
+	// ClassSelfAuthenticating.
+	Digest [28]byte
+	// Class is the principal's class, as inferred from its tag byte by
+	// Parse. Principals built by NewPrincipal/Generate are always
+	// ClassSelfAuthenticating.
+	Class Class
+	// Text is the textual principal, e.g. "aaaaa-bbbbb-...".
+	Text string
+}
+
+// NewPrincipal derives the Principal for pub at the given index and path.
+func NewPrincipal(index uint32, path string, pub crypto.PublicKey) (Principal, error) {
+	der, err := EncodePubKey(pub)
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{
+		Index:  index,
+		Path:   path,
+		DER:    der,
+		Digest: sha256.Sum224(der),
+		Class:  ClassSelfAuthenticating,
+		Text:   SelfAuthenticating(der),
+	}, nil
+}
+
+// ValidateRange reports an error if start+count would overflow a uint32,
+// which would otherwise make a `for i := start; i < start+count; i++` loop
+// silently iterate zero times instead of the intended range.
+func ValidateRange(start, count uint32) error {
+	if uint64(start)+uint64(count) > math.MaxUint32 {
+		return fmt.Errorf("start (%d) + count (%d) overflows a uint32 index", start, count)
+	}
+	return nil
+}
+
+// Generate derives count principals from the external chain (m/0) of the
+// extended public key xpub, starting at child index start.
+func Generate(xpub string, start, count uint32) ([]Principal, error) {
+	if err := ValidateRange(start, count); err != nil {
+		return nil, err
+	}
+	masterXPubKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, err
+	}
+	accountKey, err := masterXPubKey.Child(0)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Principal, 0, count)
+	for i := start; i < start+count; i++ {
+		childKey, err := accountKey.Child(i)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := childKey.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		principal, err := NewPrincipal(i, fmt.Sprintf("m/0/%d", i), pubKey)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, principal)
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package principals
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func testXPub(t *testing.T) string {
+	t.Helper()
+	seed := make([]byte, 32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	neutered, err := master.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return neutered.String()
+}
+
+func TestGenerate(t *testing.T) {
+	xpub := testXPub(t)
+
+	result, err := Generate(xpub, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("got %d principals, want 3", len(result))
+	}
+	seen := make(map[string]bool)
+	for i, principal := range result {
+		if principal.Index != uint32(i) {
+			t.Fatalf("principal %d: got index %d", i, principal.Index)
+		}
+		wantPath := fmt.Sprintf("m/0/%d", i)
+		if principal.Path != wantPath {
+			t.Fatalf("principal %d: got path %s, want %s", i, principal.Path, wantPath)
+		}
+		if principal.Class != ClassSelfAuthenticating {
+			t.Fatalf("principal %d: got class %s, want %s", i, principal.Class, ClassSelfAuthenticating)
+		}
+		if seen[principal.Text] {
+			t.Fatalf("principal %d: duplicate text %s", i, principal.Text)
+		}
+		seen[principal.Text] = true
+	}
+}
+
+func TestGenerateEmptyRange(t *testing.T) {
+	xpub := testXPub(t)
+
+	result, err := Generate(xpub, 5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 0 {
+		t.Fatalf("got %d principals, want 0", len(result))
+	}
+}
+
+func TestGenerateOverflow(t *testing.T) {
+	xpub := testXPub(t)
+
+	if _, err := Generate(xpub, math.MaxUint32-1, 5); err == nil {
+		t.Fatal("expected an error for an overflowing start+count")
+	}
+}
+
+func TestValidateRange(t *testing.T) {
+	if err := ValidateRange(0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateRange(math.MaxUint32, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateRange(math.MaxUint32-1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateRange(math.MaxUint32, 1); err == nil {
+		t.Fatal("expected an error for a range ending one past the last valid uint32 index")
+	}
+}